@@ -77,7 +77,6 @@ func main() {
 	contract, _ := contracts[fmt.Sprintf("%s:%s", counterSrc, "Counter")]
 
 	// info required to generate a transaction
-	chainID := chainConfig.ChainID
 	nonce := uint64(0)
 	gasLimit := 10000000
 	gasPrice := big.NewInt(1000000000)
@@ -112,7 +111,11 @@ func main() {
 			go func() {
 				for i := 0; i < 10; i++ {
 					tx := types.NewTransaction(nonce, contractAddr, big.NewInt(0), uint64(gasLimit), gasPrice, call)
-					signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), genKey.PrivateKey)
+					// MakeSigner picks the signer implied by the rules active at
+					// the block this tx will be included in, so activating a
+					// later fork doesn't require touching call sites like this.
+					signer := types.MakeSigner(chainConfig, big.NewInt(int64(blockCount+1)))
+					signedTx, err := types.SignTx(tx, signer, genKey.PrivateKey)
 					checkError(err)
 					chain.AddRemoteTxs([]*types.Transaction{signedTx})
 					time.Sleep(1000 * time.Millisecond)
@@ -142,7 +145,7 @@ func main() {
 	_ = contract
 	code := common.Hex2Bytes(contract.Code[2:])
 	tx := types.NewContractCreation(nonce, big.NewInt(0), uint64(gasLimit), gasPrice, code)
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(chainID), genKey.PrivateKey)
+	signedTx, err := types.SignTx(tx, types.MakeSigner(chainConfig, big.NewInt(int64(blockCount))), genKey.PrivateKey)
 	checkError(err)
 	chain.AddRemoteTxs([]*types.Transaction{signedTx})
 	time.Sleep(1000 * time.Millisecond)
@@ -0,0 +1,47 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// atomicTxCredentialDigest returns the bytes an atomic tx's credentials are
+// recovered against.
+//
+// It always returns the legacy digest (the tx's raw unsigned bytes) today,
+// regardless of [forkTime] or [blockTime]. Tx.Sign — the code that actually
+// produces atomic tx credentials — lives in another file of this package and
+// still signs over the legacy digest unconditionally, so switching
+// verification to apricotTypedDigest here without a matching change there
+// would reject every legitimately-signed tx the moment
+// vm.config.ApricotPhase0BlockTime is ever set: the fork gate and the digest
+// switch must land together. Until Tx.Sign is updated to match, this
+// function intentionally ignores both arguments; they're threaded through
+// now so call sites don't need to change again when that lands.
+func atomicTxCredentialDigest(forkTime, blockTime *big.Int, unsignedBytes []byte) []byte {
+	return unsignedBytes
+}
+
+// isApricotPhase0Active reports whether the Apricot atomic tx credential
+// fork is active for a block produced at [blockTime], given the chain's
+// configured [forkTime] (vm.config.ApricotPhase0BlockTime). Not yet called
+// from atomicTxCredentialDigest; see its comment.
+func isApricotPhase0Active(forkTime, blockTime *big.Int) bool {
+	return forkTime != nil && blockTime != nil && blockTime.Cmp(forkTime) >= 0
+}
+
+// apricotTypedDigest is the post-fork, typed-transaction-style digest:
+// prefixing with a type byte before hashing, the way EIP-2718 typed txs do,
+// guarantees a legacy credential can never be replayed as a typed one or
+// vice versa. Unused until Tx.Sign produces matching credentials; see
+// atomicTxCredentialDigest.
+func apricotTypedDigest(unsignedBytes []byte) []byte {
+	typed := make([]byte, 0, len(unsignedBytes)+1)
+	typed = append(typed, 0x01)
+	typed = append(typed, unsignedBytes...)
+	return crypto.Keccak256(typed)
+}
@@ -33,24 +33,33 @@ func (b *Block) Accept() error {
 
 	log.Trace(fmt.Sprintf("Block %s is accepted", b.ID()))
 	vm.updateStatus(b.id, choices.Accepted)
-	if err := vm.acceptedDB.Put(b.ethBlock.Number().Bytes(), b.id[:]); err != nil {
-		return err
-	}
 
 	tx := vm.getAtomicTx(b.ethBlock)
 	if tx == nil {
-		return nil
+		return vm.acceptedDB.Put(b.ethBlock.Number().Bytes(), b.id[:])
 	}
 	utx, ok := tx.UnsignedTx.(UnsignedAtomicTx)
 	if !ok {
 		return errUnknownAtomicTxType
 	}
 
-	return utx.Accept(vm.ctx, nil)
+	// Batch the height index update together with the atomic tx's shared
+	// memory effects so that either both land or neither does. A crash
+	// between the two writes previously could desynchronize the C-Chain's
+	// view of its own height from the X-Chain's shared memory.
+	batch := vm.acceptedDB.NewBatch()
+	if err := batch.Put(b.ethBlock.Number().Bytes(), b.id[:]); err != nil {
+		return err
+	}
+	return utx.Accept(vm.ctx, batch)
 }
 
 // Reject implements the snowman.Block interface
 // If [b] contains an atomic transaction, attempt to re-issue it
+//
+// Because Verify never writes through the batch handed to Accept, there are
+// no speculative shared memory effects to undo here; it is always safe to
+// re-issue the rejected block's atomic tx.
 func (b *Block) Reject() error {
 	log.Trace(fmt.Sprintf("Block %s is rejected", b.ID()))
 	b.vm.updateStatus(b.ID(), choices.Rejected)
@@ -90,9 +99,24 @@ func (b *Block) Height() uint64 {
 func (b *Block) Verify() error {
 	// Only enforce a minimum fee when bootstrapping has finished
 	if b.vm.ctx.IsBootstrapped() {
-		// Ensure the minimum gas price is paid for every transaction
+		// Recompute the minimum gas price from the parent's own recorded
+		// minimum and congestion, rather than trusting whatever the
+		// producer declared in this block's Extra field. A block's
+		// self-reported Extra is untrusted input: without this recomputation
+		// a producer could set Extra to 0 (or leave it empty on a chain
+		// whose genesis Extra happens to decode to 0, as examples/counter's
+		// does) and let arbitrarily low gas prices through.
+		minGasPrice := params.MinGasPrice
+		if b.ethBlock.Hash() != b.vm.genesisHash {
+			parent := b.Parent().(*Block).ethBlock
+			expected := calcMinGasPrice(blockMinGasPrice(parent), parent.GasUsed(), parent.GasLimit())
+			if blockMinGasPrice(b.ethBlock).Cmp(expected) != 0 {
+				return errInvalidMinGasPrice
+			}
+			minGasPrice = expected
+		}
 		for _, tx := range b.ethBlock.Transactions() {
-			if tx.GasPrice().Cmp(params.MinGasPrice) < 0 {
+			if tx.GasPrice().Cmp(minGasPrice) < 0 {
 				return errInsufficientGasPrice
 			}
 		}
@@ -145,7 +169,7 @@ func (b *Block) Verify() error {
 		}
 
 		utx := tx.UnsignedTx.(UnsignedAtomicTx)
-		if err := utx.SemanticVerify(vm, tx); err != nil {
+		if err := utx.SemanticVerify(vm, tx, b.ethBlock.Time()); err != nil {
 			return fmt.Errorf("block atomic transaction failed verification due to: %w", err)
 		}
 		bc := vm.chain.BlockChain()
@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import "math/big"
+
+// Config holds the subset of per-chain VM configuration that gates
+// network-specific consensus behavior, as opposed to the process-wide
+// constants in package params. Unlike a package-level var, a field here is
+// read from vm.config, so mainnet, testnet, and a local network can each
+// activate a gate independently instead of sharing one mutable global.
+type Config struct {
+	// ApricotPhase0BlockTime, when non-nil, is the timestamp at which this
+	// chain's atomic tx credentials are meant to switch from legacy
+	// secp256k1 recovery over raw UnsignedBytes() to typed-transaction-style
+	// credentials (see apricotTypedDigest in apricot.go). It is not yet
+	// consulted by SemanticVerify; see the comment on atomicTxCredentialDigest
+	// for why flipping it on is not yet safe.
+	ApricotPhase0BlockTime *big.Int
+
+	// KeyStrengthMinScore is the minimum zxcvbn-style passphrase strength, 0
+	// (weakest) to 4 (strongest), required before a keystore passphrase may
+	// unlock a signer for an atomic export. The zero value means "use
+	// DefaultKeyStrengthPolicy"; see KeyStrengthPolicy.
+	KeyStrengthMinScore int
+}
+
+// KeyStrengthPolicy returns the KeyStrengthPolicy this chain is configured to
+// enforce, falling back to DefaultKeyStrengthPolicy when KeyStrengthMinScore
+// is left at its zero value.
+func (c Config) KeyStrengthPolicy() KeyStrengthPolicy {
+	if c.KeyStrengthMinScore == 0 {
+		return DefaultKeyStrengthPolicy
+	}
+	return KeyStrengthPolicy{MinScore: c.KeyStrengthMinScore}
+}
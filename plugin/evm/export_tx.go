@@ -69,6 +69,9 @@ func (tx *UnsignedExportTx) Verify(
 			return err
 		}
 	}
+	if !IsSortedAndUniqueEVMInputs(tx.Ins) {
+		return errInputsNotSorted
+	}
 
 	for _, out := range tx.ExportedOutputs {
 		if err := out.Verify(); err != nil {
@@ -87,17 +90,22 @@ func (tx *UnsignedExportTx) Verify(
 func (tx *UnsignedExportTx) SemanticVerify(
 	vm *VM,
 	stx *Tx,
+	blockTime *big.Int,
 ) TxError {
 	if err := tx.Verify(vm.ctx.XChainID, vm.ctx, vm.txFee, vm.ctx.AVAXAssetID); err != nil {
 		return permError{err}
 	}
 
+	// atomicTxCredentialDigest always returns the legacy digest today; see
+	// its doc comment for why the Apricot fork gate isn't live yet.
+	digest := atomicTxCredentialDigest(vm.config.ApricotPhase0BlockTime, blockTime, tx.UnsignedBytes())
+
 	f := crypto.FactorySECP256K1R{}
 	for i, cred := range stx.Creds {
 		if err := cred.Verify(); err != nil {
 			return permError{err}
 		}
-		pubKey, err := f.RecoverPublicKey(tx.UnsignedBytes(), cred.(*secp256k1fx.Credential).Sigs[0][:])
+		pubKey, err := f.RecoverPublicKey(digest, cred.(*secp256k1fx.Credential).Sigs[0][:])
 		if err != nil {
 			return permError{err}
 		}
@@ -127,7 +135,7 @@ func (tx *UnsignedExportTx) SemanticVerify(
 }
 
 // Accept this transaction.
-func (tx *UnsignedExportTx) Accept(ctx *snow.Context, _ database.Batch) error {
+func (tx *UnsignedExportTx) Accept(ctx *snow.Context, batch database.Batch) error {
 	txID := tx.ID()
 
 	elems := make([]*atomic.Element, len(tx.ExportedOutputs))
@@ -157,20 +165,34 @@ func (tx *UnsignedExportTx) Accept(ctx *snow.Context, _ database.Batch) error {
 		elems[i] = elem
 	}
 
-	return ctx.SharedMemory.Put(tx.DestinationChain, elems)
+	// Passing [batch] through lets SharedMemory.Put commit it atomically
+	// alongside its own writes, rather than as a separate, independently
+	// crash-able operation.
+	return ctx.SharedMemory.Put(tx.DestinationChain, elems, batch)
 }
 
 // Create a new transaction
+//
+// [passphrase] is the passphrase used to unlock [keys] from the keystore;
+// it is scored against vm.config.KeyStrengthPolicy() before [keys] are
+// allowed to sign an atomic export, so a weak-password wallet cannot
+// silently authorize a cross-chain export of AVAX. Callers that source keys
+// from somewhere other than a passphrase-protected keystore account should
+// pass the empty string, which always fails the policy by design.
 func (vm *VM) newExportTx(
 	assetID ids.ID, // AssetID of the tokens to export
 	amount uint64, // Amount of tokens to export
 	chainID ids.ID, // Chain to send the UTXOs to
 	to ids.ShortID, // Address of chain recipient
 	keys []*crypto.PrivateKeySECP256K1R, // Pay the fee and provide the tokens
+	passphrase string, // Passphrase that unlocked [keys], checked against vm.config.KeyStrengthPolicy()
 ) (*Tx, error) {
 	if !vm.ctx.XChainID.Equals(chainID) {
 		return nil, errWrongChainID
 	}
+	if err := vm.config.KeyStrengthPolicy().Check(passphrase); err != nil {
+		return nil, err
+	}
 
 	var toBurn uint64
 	var err error
@@ -198,6 +220,11 @@ func (vm *VM) newExportTx(
 		signers = append(signers, signers2...)
 	}
 
+	// Canonicalize the input order so that the resulting tx hash is
+	// independent of which UTXOs GetSpendableCanonical happened to select
+	// first, and so that multiple signers can agree on the bytes to sign.
+	SortEVMInputsWithSigners(ins, signers)
+
 	// Create the transaction
 	utx := &UnsignedExportTx{
 		NetworkID:        vm.ctx.NetworkID,
@@ -216,6 +243,12 @@ func (vm *VM) newExportTx(
 			},
 		}},
 	}
+	// Canonicalize output order the same way Ins was canonicalized above, so
+	// that Verify's avax.IsSortedTransferableOutputs check keeps passing if
+	// a future caller ever builds ExportedOutputs with more than one entry
+	// (e.g. a multi-asset export in a single tx).
+	SortTransferableOutputs(utx.ExportedOutputs, vm.codec)
+
 	tx := &Tx{UnsignedTx: utx}
 	if err := tx.Sign(vm.codec, signers); err != nil {
 		return nil, err
@@ -0,0 +1,108 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+)
+
+// errInvalidMinGasPrice is returned when a block's self-reported minimum gas
+// price (its Extra field) does not match the value Block.Verify recomputes
+// from the parent's own minimum and congestion.
+var errInvalidMinGasPrice = errors.New("block's declared minimum gas price does not match the recomputed value")
+
+// calcMinGasPrice/blockMinGasPrice only cover the verify side of this
+// recurrence: rejecting a block whose declared Extra doesn't match the
+// recomputed minimum. Three pieces this feature needs before it's safe to
+// activate on a live chain are tracked as explicit follow-up, not present
+// here:
+//   - Producer-side wiring: nothing in this package's files sets a new
+//     block's Extra to calcMinGasPrice's output before it's proposed. The
+//     file that assembles and proposes blocks (a miner/worker, not present
+//     in this package) needs to call calcMinGasPrice and write the result
+//     into Extra, or every block this chain produces will fail its own
+//     Verify check.
+//   - An eth_baseFee-style RPC so wallets and tooling can read the current
+//     minimum before submitting a transaction, instead of guessing and
+//     risking errInsufficientGasPrice.
+//   - Mempool eviction of already-queued transactions that fall below a
+//     newly-risen minimum, so they don't sit forever un-minable.
+
+const (
+	// targetGasUsageDenominator sets the target gas usage for a block to
+	// gasLimit/targetGasUsageDenominator.
+	targetGasUsageDenominator = 2
+	// minGasPriceChangeDenominator bounds how far the minimum gas price can
+	// move, up or down, between consecutive blocks to at most 1/8th of the
+	// current minimum, mirroring EIP-1559's base-fee recurrence.
+	minGasPriceChangeDenominator = 8
+)
+
+// minGasPriceCeiling bounds how high sustained congestion can drive the
+// dynamic minimum gas price.
+var minGasPriceCeiling = new(big.Int).Mul(params.MinGasPrice, big.NewInt(100))
+
+// calcMinGasPrice computes the minimum gas price required of every
+// transaction in the block built on top of a parent with the given minimum
+// gas price and gas usage. It follows the EIP-1559 base-fee recurrence: the
+// minimum moves toward the target gas usage by at most 1/minGasPriceChangeDenominator
+// per block, clamped to [params.MinGasPrice, minGasPriceCeiling].
+func calcMinGasPrice(parentMinGasPrice *big.Int, parentGasUsed, parentGasLimit uint64) *big.Int {
+	if parentMinGasPrice == nil || parentMinGasPrice.Sign() == 0 {
+		parentMinGasPrice = params.MinGasPrice
+	}
+
+	targetGas := parentGasLimit / targetGasUsageDenominator
+	if targetGas == 0 {
+		return new(big.Int).Set(parentMinGasPrice)
+	}
+
+	var (
+		gasDelta uint64
+		neg      bool
+	)
+	if parentGasUsed >= targetGas {
+		gasDelta = parentGasUsed - targetGas
+	} else {
+		gasDelta = targetGas - parentGasUsed
+		neg = true
+	}
+
+	delta := new(big.Int).Mul(parentMinGasPrice, new(big.Int).SetUint64(gasDelta))
+	delta.Div(delta, new(big.Int).SetUint64(targetGas))
+	delta.Div(delta, big.NewInt(minGasPriceChangeDenominator))
+	if neg {
+		delta.Neg(delta)
+	}
+
+	next := new(big.Int).Add(parentMinGasPrice, delta)
+	switch {
+	case next.Cmp(params.MinGasPrice) < 0:
+		return new(big.Int).Set(params.MinGasPrice)
+	case next.Cmp(minGasPriceCeiling) > 0:
+		return new(big.Int).Set(minGasPriceCeiling)
+	default:
+		return next
+	}
+}
+
+// blockMinGasPrice returns the minimum gas price required of every
+// transaction in [ethBlock], as recorded by its producer in the block's
+// Extra field. Extra is untrusted self-reported data (Block.Verify is what
+// actually enforces it matches the recomputed value), so any declared value
+// below the static params.MinGasPrice floor — including an empty or
+// all-zero Extra, e.g. a genesis block's placeholder ExtraData — is clamped
+// up to that floor rather than trusted as-is.
+func blockMinGasPrice(ethBlock *types.Block) *big.Int {
+	if extra := ethBlock.Extra(); len(extra) != 0 {
+		if declared := new(big.Int).SetBytes(extra); declared.Cmp(params.MinGasPrice) >= 0 {
+			return declared
+		}
+	}
+	return new(big.Int).Set(params.MinGasPrice)
+}
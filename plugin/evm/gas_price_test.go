@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+)
+
+func TestCalcMinGasPriceAtTarget(t *testing.T) {
+	parent := big.NewInt(1_000_000_000)
+	got := calcMinGasPrice(parent, 5_000_000, 10_000_000)
+	if got.Cmp(parent) != 0 {
+		t.Fatalf("expected minimum to stay at %s when gas used equals target, got %s", parent, got)
+	}
+}
+
+func TestCalcMinGasPriceRisesUnderCongestion(t *testing.T) {
+	parent := big.NewInt(1_000_000_000)
+	got := calcMinGasPrice(parent, 10_000_000, 10_000_000) // fully used, target is half
+	if got.Cmp(parent) <= 0 {
+		t.Fatalf("expected minimum to rise above %s when fully congested, got %s", parent, got)
+	}
+}
+
+func TestCalcMinGasPriceFallsWhenIdle(t *testing.T) {
+	parent := big.NewInt(2_000_000_000)
+	got := calcMinGasPrice(parent, 0, 10_000_000) // empty block, target is half
+	if got.Cmp(parent) >= 0 {
+		t.Fatalf("expected minimum to fall below %s when idle, got %s", parent, got)
+	}
+}
+
+func TestCalcMinGasPriceFloor(t *testing.T) {
+	// A small parent minimum whose 1/8th delta truncates to 0 under integer
+	// division must still be clamped at params.MinGasPrice, never drift below
+	// it or get stuck at an arbitrarily small value.
+	parent := big.NewInt(1)
+	got := calcMinGasPrice(parent, 0, 10_000_000)
+	if got.Cmp(params.MinGasPrice) < 0 {
+		t.Fatalf("expected minimum to be clamped to the %s floor, got %s", params.MinGasPrice, got)
+	}
+}
+
+func TestCalcMinGasPriceCeiling(t *testing.T) {
+	parent := new(big.Int).Set(minGasPriceCeiling)
+	got := calcMinGasPrice(parent, 10_000_000, 10_000_000)
+	if got.Cmp(minGasPriceCeiling) > 0 {
+		t.Fatalf("expected minimum to be clamped to the %s ceiling, got %s", minGasPriceCeiling, got)
+	}
+}
+
+func TestBlockMinGasPriceClampsLowDeclaredValue(t *testing.T) {
+	// A declared value below the floor (including the all-zero bytes a
+	// genesis block's placeholder ExtraData decodes to) must never be
+	// trusted as-is; it should be clamped up to params.MinGasPrice.
+	zeroExtra := types.NewBlockWithHeader(&types.Header{Extra: []byte{0x00}})
+	if got := blockMinGasPrice(zeroExtra); got.Cmp(params.MinGasPrice) != 0 {
+		t.Fatalf("expected all-zero Extra to clamp to %s, got %s", params.MinGasPrice, got)
+	}
+
+	emptyExtra := types.NewBlockWithHeader(&types.Header{})
+	if got := blockMinGasPrice(emptyExtra); got.Cmp(params.MinGasPrice) != 0 {
+		t.Fatalf("expected empty Extra to fall back to %s, got %s", params.MinGasPrice, got)
+	}
+
+	declared := new(big.Int).Add(params.MinGasPrice, big.NewInt(1))
+	highExtra := types.NewBlockWithHeader(&types.Header{Extra: declared.Bytes()})
+	if got := blockMinGasPrice(highExtra); got.Cmp(declared) != 0 {
+		t.Fatalf("expected a valid declared value of %s to pass through, got %s", declared, got)
+	}
+}
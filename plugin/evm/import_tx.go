@@ -0,0 +1,150 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/state"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	errNoImportInputs        = errors.New("tx has no imported inputs")
+	errInputsNotSortedUnique = errors.New("imported inputs not sorted and unique")
+)
+
+// EVMOutput credits [Address] with [Amount] of [AssetID] when an ImportTx is
+// accepted, mirroring the shape of EVMInput used by ExportTx.
+type EVMOutput struct {
+	Address common.Address `serialize:"true" json:"address"`
+	Amount  uint64          `serialize:"true" json:"amount"`
+	AssetID ids.ID          `serialize:"true" json:"assetID"`
+}
+
+// UnsignedImportTx is an unsigned ImportTx
+type UnsignedImportTx struct {
+	avax.Metadata
+	// ID of the network on which this tx was issued
+	NetworkID uint32 `serialize:"true" json:"networkID"`
+	// ID of this blockchain.
+	BlockchainID ids.ID `serialize:"true" json:"blockchainID"`
+	// Which chain the funds are being imported from
+	SourceChain ids.ID `serialize:"true" json:"sourceChain"`
+	// Inputs this tx is importing, pulled as UTXOs from [SourceChain]'s
+	// shared memory
+	ImportedInputs []*avax.TransferableInput `serialize:"true" json:"importedInputs"`
+	// Outputs this tx credits on the C-Chain
+	Outs []EVMOutput `serialize:"true" json:"outputs"`
+}
+
+// InputUTXOs returns the UTXOIDs consumed by this tx, so Block.Verify can
+// detect conflicting imports across blocks still being verified.
+func (tx *UnsignedImportTx) InputUTXOs() ids.Set {
+	set := ids.Set{}
+	for _, in := range tx.ImportedInputs {
+		set.Add(in.InputID())
+	}
+	return set
+}
+
+// Verify this transaction is well-formed
+func (tx *UnsignedImportTx) Verify(
+	avmID ids.ID,
+	ctx *snow.Context,
+	feeAmount uint64,
+	feeAssetID ids.ID,
+) error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case tx.SourceChain.IsZero():
+		return errWrongChainID
+	case !tx.SourceChain.Equals(avmID):
+		return errWrongChainID
+	case len(tx.ImportedInputs) == 0:
+		return errNoImportInputs
+	case tx.NetworkID != ctx.NetworkID:
+		return errWrongNetworkID
+	case !ctx.ChainID.Equals(tx.BlockchainID):
+		return errWrongBlockchainID
+	}
+
+	for _, in := range tx.ImportedInputs {
+		if err := in.Verify(); err != nil {
+			return err
+		}
+	}
+	if !avax.IsSortedAndUniqueTransferableInputs(tx.ImportedInputs) {
+		return errInputsNotSortedUnique
+	}
+
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UnsignedImportTx) SemanticVerify(
+	vm *VM,
+	stx *Tx,
+	blockTime *big.Int,
+) TxError {
+	if err := tx.Verify(vm.ctx.XChainID, vm.ctx, vm.txFee, vm.ctx.AVAXAssetID); err != nil {
+		return permError{err}
+	}
+
+	// Use the same atomicTxCredentialDigest as ExportTx, so both atomic tx
+	// types would switch credential semantics at the same fork boundary
+	// once that switch is safe to enable; see its doc comment.
+	digest := atomicTxCredentialDigest(vm.config.ApricotPhase0BlockTime, blockTime, tx.UnsignedBytes())
+
+	f := crypto.FactorySECP256K1R{}
+	for _, cred := range stx.Creds {
+		if err := cred.Verify(); err != nil {
+			return permError{err}
+		}
+		if _, err := f.RecoverPublicKey(digest, cred.(*secp256k1fx.Credential).Sigs[0][:]); err != nil {
+			return permError{err}
+		}
+	}
+
+	// TODO: verify imported UTXO ownership and flow-check amounts via gRPC
+	return nil
+}
+
+// Accept this transaction, removing the imported UTXOs from
+// [tx.SourceChain]'s shared memory through [batch] so the removal commits
+// atomically with the rest of the block's effects (the height index update)
+// — the other half of the atomicity guarantee introduced for ExportTx.Accept.
+func (tx *UnsignedImportTx) Accept(ctx *snow.Context, batch database.Batch) error {
+	utxoIDs := make([][]byte, len(tx.ImportedInputs))
+	for i, in := range tx.ImportedInputs {
+		inputID := in.InputID()
+		utxoIDs[i] = inputID[:]
+	}
+	return ctx.SharedMemory.Remove(tx.SourceChain, utxoIDs, batch)
+}
+
+// EVMStateTransfer credits the imported funds to their recipients' EVM
+// balances.
+func (tx *UnsignedImportTx) EVMStateTransfer(vm *VM, state *state.StateDB) error {
+	for _, to := range tx.Outs {
+		log.Info("crosschain X->C", "addr", to.Address, "amount", to.Amount)
+		amount := new(big.Int).Mul(new(big.Int).SetUint64(to.Amount), x2cRate)
+		if to.AssetID == vm.ctx.AVAXAssetID {
+			state.AddBalance(to.Address, amount)
+		} else {
+			state.AddBalanceMultiCoin(to.Address, to.AssetID.Key(), amount)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// KeyStrengthPolicy rejects passphrases that score below MinScore on a
+// zxcvbn-style 0-4 strength scale before the key material they protect is
+// allowed to be persisted or used to sign an atomic tx.
+type KeyStrengthPolicy struct {
+	// MinScore is the minimum acceptable strength score, 0 (weakest) to 4
+	// (strongest).
+	MinScore int
+}
+
+// DefaultKeyStrengthPolicy requires at least a "good" passphrase before a
+// keystore-derived signer can export AVAX cross-chain.
+var DefaultKeyStrengthPolicy = KeyStrengthPolicy{MinScore: 3}
+
+// ErrWeakPassphrase is returned when a passphrase does not meet a
+// KeyStrengthPolicy's MinScore.
+type ErrWeakPassphrase struct {
+	Score            int
+	CrackTimeSeconds float64
+	Suggestions      []string
+}
+
+func (e *ErrWeakPassphrase) Error() string {
+	return fmt.Sprintf(
+		"passphrase strength score %d is below the required minimum (estimated crack time: %.0fs); suggestions: %v",
+		e.Score, e.CrackTimeSeconds, e.Suggestions,
+	)
+}
+
+// Check scores [passphrase] and returns an *ErrWeakPassphrase if it falls
+// below [p].MinScore.
+func (p KeyStrengthPolicy) Check(passphrase string) error {
+	result := estimatePassphraseStrength(passphrase)
+	if result.Score < p.MinScore {
+		return &ErrWeakPassphrase{
+			Score:            result.Score,
+			CrackTimeSeconds: result.CrackTimeSeconds,
+			Suggestions:      result.Suggestions,
+		}
+	}
+	return nil
+}
+
+// passphraseStrength is the subset of a zxcvbn-style estimate this package
+// needs: a 0-4 score, an estimated offline crack time, and suggestions for
+// strengthening the passphrase.
+type passphraseStrength struct {
+	Score            int
+	CrackTimeSeconds float64
+	Suggestions      []string
+}
+
+// commonPasswordPatterns are dictionary words and well-known passwords that,
+// per-character-class entropy alone scores deceptively high once a digit or
+// symbol is appended or substituted in (e.g. "Password1!"). zxcvbn's
+// dictionary matcher dominates its score the same way: a known word, however
+// it's decorated, should never read as strong.
+var commonPasswordPatterns = []string{
+	"password", "passw0rd", "letmein", "qwerty", "123456", "12345678",
+	"admin", "welcome", "avalanche", "coreth", "secret", "iloveyou",
+	"monkey", "dragon", "sunshine", "football", "baseball", "trustno1",
+}
+
+// commonPatternReplacer undoes the small set of leet-speak substitutions
+// (0->o, 1->i, and so on) that a dictionary word is most often decorated
+// with, so "P@ssw0rd1!" still normalizes to a string containing "password".
+var commonPatternReplacer = strings.NewReplacer(
+	"0", "o", "1", "i", "3", "e", "4", "a", "5", "s",
+	"@", "a", "$", "s", "!", "i",
+)
+
+// containsCommonPattern reports whether [passphrase], after lowercasing and
+// undoing common leet-speak substitutions, contains one of
+// commonPasswordPatterns (normalized the same way).
+func containsCommonPattern(passphrase string) bool {
+	normalized := strings.ToLower(commonPatternReplacer.Replace(passphrase))
+	for _, pattern := range commonPasswordPatterns {
+		if strings.Contains(normalized, commonPatternReplacer.Replace(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// estimatePassphraseStrength scores [passphrase] using simple entropy and
+// pattern heuristics in the same 0-4 band as zxcvbn, without pulling in the
+// full estimator. It is intentionally conservative: short or low-variety
+// passphrases score low even if they'd pass a naive length check, and a
+// passphrase matching commonPasswordPatterns is capped low regardless of how
+// much entropy its character-class mix would otherwise imply.
+func estimatePassphraseStrength(passphrase string) passphraseStrength {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range passphrase {
+		switch {
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	poolSize := 0
+	if hasLower {
+		poolSize += 26
+	}
+	if hasUpper {
+		poolSize += 26
+	}
+	if hasDigit {
+		poolSize += 10
+	}
+	if hasSymbol {
+		poolSize += 33
+	}
+	if poolSize == 0 {
+		return passphraseStrength{Score: 0, CrackTimeSeconds: 0, Suggestions: []string{"use a longer passphrase"}}
+	}
+
+	// bitsOfEntropy approximates log2(poolSize^len(passphrase)).
+	bitsOfEntropy := float64(len(passphrase)) * math.Log2(float64(poolSize))
+
+	// Assume an offline attacker at 10^10 guesses/sec, as zxcvbn does for its
+	// slowest-hash scenario.
+	const guessesPerSecond = 1e10
+	crackTimeSeconds := math.Pow(2, bitsOfEntropy) / guessesPerSecond
+
+	var score int
+	switch {
+	case crackTimeSeconds < 1:
+		score = 0
+	case crackTimeSeconds < 60*60:
+		score = 1
+	case crackTimeSeconds < 60*60*24:
+		score = 2
+	case crackTimeSeconds < 60*60*24*90:
+		score = 3
+	default:
+		score = 4
+	}
+
+	var suggestions []string
+	if len(passphrase) < 12 {
+		suggestions = append(suggestions, "use a longer passphrase")
+	}
+	if !hasSymbol {
+		suggestions = append(suggestions, "add symbols")
+	}
+	if !hasDigit {
+		suggestions = append(suggestions, "add numbers")
+	}
+	if !hasUpper || !hasLower {
+		suggestions = append(suggestions, "mix upper and lower case")
+	}
+
+	if containsCommonPattern(passphrase) {
+		if score > 1 {
+			score = 1
+		}
+		if crackTimeSeconds > 60 {
+			crackTimeSeconds = 60
+		}
+		suggestions = append([]string{"avoid common words and simple substitutions"}, suggestions...)
+	}
+
+	return passphraseStrength{Score: score, CrackTimeSeconds: crackTimeSeconds, Suggestions: suggestions}
+}
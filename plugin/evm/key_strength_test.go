@@ -0,0 +1,53 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import "testing"
+
+func TestKeyStrengthPolicyRejectsWeakPassphrases(t *testing.T) {
+	policy := KeyStrengthPolicy{MinScore: 3}
+
+	for _, passphrase := range []string{"", "a", "password", "12345678"} {
+		if err := policy.Check(passphrase); err == nil {
+			t.Fatalf("expected passphrase %q to be rejected", passphrase)
+		} else if _, ok := err.(*ErrWeakPassphrase); !ok {
+			t.Fatalf("expected *ErrWeakPassphrase for %q, got %T", passphrase, err)
+		}
+	}
+}
+
+func TestKeyStrengthPolicyRejectsDecoratedDictionaryWord(t *testing.T) {
+	policy := KeyStrengthPolicy{MinScore: 3}
+
+	for _, passphrase := range []string{"Password1!", "Avalanche123!"} {
+		if err := policy.Check(passphrase); err == nil {
+			t.Fatalf("expected decorated dictionary passphrase %q to be rejected", passphrase)
+		}
+	}
+}
+
+func TestKeyStrengthPolicyAcceptsStrongPassphrase(t *testing.T) {
+	policy := KeyStrengthPolicy{MinScore: 3}
+
+	if err := policy.Check("correct horse battery staple 42!"); err != nil {
+		t.Fatalf("expected a long, high-entropy passphrase to pass, got %v", err)
+	}
+}
+
+func TestKeyStrengthPolicyMinScoreZeroAcceptsAnything(t *testing.T) {
+	policy := KeyStrengthPolicy{MinScore: 0}
+
+	if err := policy.Check(""); err != nil {
+		t.Fatalf("expected MinScore 0 to accept even an empty passphrase, got %v", err)
+	}
+}
+
+func TestEstimatePassphraseStrengthMonotonicWithLength(t *testing.T) {
+	short := estimatePassphraseStrength("abc")
+	long := estimatePassphraseStrength("abcabcabcabcabcabcabcabcabcabc")
+
+	if long.Score < short.Score {
+		t.Fatalf("expected a longer passphrase to score at least as high: short=%d long=%d", short.Score, long.Score)
+	}
+}
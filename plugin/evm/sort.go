@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// errInputsNotSorted is returned when a tx's EVMInputs are not in canonical
+// order, which would make the tx hash depend on which UTXOs/keys the
+// submitting client happened to spend first.
+var errInputsNotSorted = errors.New("inputs not sorted")
+
+// innerSortEVMInputsWithSigners sorts EVMInputs and co-permutes the signers
+// slice so that signers[i] still signs for ins[i] after sorting.
+type innerSortEVMInputsWithSigners struct {
+	ins     []EVMInput
+	signers [][]*crypto.PrivateKeySECP256K1R
+}
+
+func (s *innerSortEVMInputsWithSigners) Less(i, j int) bool {
+	iIn, jIn := s.ins[i], s.ins[j]
+
+	switch bytes.Compare(iIn.AssetID.Bytes(), jIn.AssetID.Bytes()) {
+	case -1:
+		return true
+	case 1:
+		return false
+	}
+
+	switch bytes.Compare(iIn.Address.Bytes(), jIn.Address.Bytes()) {
+	case -1:
+		return true
+	case 1:
+		return false
+	}
+
+	return iIn.Nonce < jIn.Nonce
+}
+
+func (s *innerSortEVMInputsWithSigners) Len() int { return len(s.ins) }
+
+func (s *innerSortEVMInputsWithSigners) Swap(i, j int) {
+	s.ins[i], s.ins[j] = s.ins[j], s.ins[i]
+	s.signers[i], s.signers[j] = s.signers[j], s.signers[i]
+}
+
+// SortEVMInputsWithSigners sorts the inputs lexicographically by (AssetID,
+// Address, Nonce), co-permuting [signers] so that signers[i] still matches
+// ins[i] after sorting. This must be used wherever EVMInputs are assembled
+// so that tx hashes are deterministic regardless of which UTXOs/keys a
+// particular builder happened to spend first.
+func SortEVMInputsWithSigners(ins []EVMInput, signers [][]*crypto.PrivateKeySECP256K1R) {
+	sort.Stable(&innerSortEVMInputsWithSigners{ins: ins, signers: signers})
+}
+
+// IsSortedAndUniqueEVMInputs returns true iff [ins] is sorted according to
+// SortEVMInputsWithSigners and contains no duplicate (AssetID, Address,
+// Nonce) entries.
+func IsSortedAndUniqueEVMInputs(ins []EVMInput) bool {
+	for i := 0; i < len(ins)-1; i++ {
+		iIn, jIn := ins[i], ins[i+1]
+
+		assetCmp := bytes.Compare(iIn.AssetID.Bytes(), jIn.AssetID.Bytes())
+		if assetCmp > 0 {
+			return false
+		}
+		if assetCmp < 0 {
+			continue
+		}
+
+		addrCmp := bytes.Compare(iIn.Address.Bytes(), jIn.Address.Bytes())
+		if addrCmp > 0 {
+			return false
+		}
+		if addrCmp < 0 {
+			continue
+		}
+
+		if iIn.Nonce >= jIn.Nonce {
+			return false
+		}
+	}
+	return true
+}
+
+// SortTransferableOutputs sorts [outs] canonically using [codec]. newExportTx
+// calls it so that ExportedOutputs are always built in the order
+// IsSortedTransferableOutputs expects.
+func SortTransferableOutputs(outs []*avax.TransferableOutput, c codec.Manager) {
+	avax.SortTransferableOutputs(outs, c)
+}
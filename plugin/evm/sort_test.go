@@ -0,0 +1,80 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func key(t *testing.T) *crypto.PrivateKeySECP256K1R {
+	t.Helper()
+	factory := crypto.FactorySECP256K1R{}
+	sk, err := factory.NewPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk.(*crypto.PrivateKeySECP256K1R)
+}
+
+func TestSortEVMInputsWithSignersCoPermutes(t *testing.T) {
+	assetA := ids.ID{1}
+	assetB := ids.ID{2}
+	addrLow := common.Address{1}
+	addrHigh := common.Address{2}
+
+	ins := []EVMInput{
+		{AssetID: assetB, Address: addrLow, Nonce: 0, Amount: 1},
+		{AssetID: assetA, Address: addrHigh, Nonce: 1, Amount: 1},
+		{AssetID: assetA, Address: addrHigh, Nonce: 0, Amount: 1},
+		{AssetID: assetA, Address: addrLow, Nonce: 0, Amount: 1},
+	}
+	k0, k1, k2, k3 := key(t), key(t), key(t), key(t)
+	signers := [][]*crypto.PrivateKeySECP256K1R{{k0}, {k1}, {k2}, {k3}}
+
+	// Remember which signer belongs to which logical input before sorting.
+	want := map[EVMInput]*crypto.PrivateKeySECP256K1R{
+		ins[0]: k0,
+		ins[1]: k1,
+		ins[2]: k2,
+		ins[3]: k3,
+	}
+
+	SortEVMInputsWithSigners(ins, signers)
+
+	if !IsSortedAndUniqueEVMInputs(ins) {
+		t.Fatalf("inputs not sorted after SortEVMInputsWithSigners: %+v", ins)
+	}
+	for i, in := range ins {
+		if signers[i][0] != want[in] {
+			t.Fatalf("signer at index %d does not match its input after sort", i)
+		}
+	}
+}
+
+func TestIsSortedAndUniqueEVMInputsRejectsDuplicates(t *testing.T) {
+	assetA := ids.ID{1}
+	addr := common.Address{1}
+
+	ins := []EVMInput{
+		{AssetID: assetA, Address: addr, Nonce: 0, Amount: 1},
+		{AssetID: assetA, Address: addr, Nonce: 0, Amount: 1},
+	}
+	if IsSortedAndUniqueEVMInputs(ins) {
+		t.Fatal("expected duplicate (AssetID, Address, Nonce) inputs to be rejected")
+	}
+}
+
+func TestIsSortedAndUniqueEVMInputsEmptyAndSingle(t *testing.T) {
+	if !IsSortedAndUniqueEVMInputs(nil) {
+		t.Fatal("expected empty input slice to be considered sorted")
+	}
+	ins := []EVMInput{{AssetID: ids.ID{1}, Address: common.Address{1}, Nonce: 0, Amount: 1}}
+	if !IsSortedAndUniqueEVMInputs(ins) {
+		t.Fatal("expected single-element input slice to be considered sorted")
+	}
+}